@@ -81,6 +81,13 @@ func appRoleAssignmentResource() *pluginsdk.Resource {
 				Type:        pluginsdk.TypeString,
 				Computed:    true,
 			},
+
+			"retry_on_missing_principal": {
+				Description: "Retry creation if the principal or resource service principal has not yet replicated, up to the create timeout",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
 		},
 	}
 }
@@ -106,12 +113,21 @@ func appRoleAssignmentResourceCreate(ctx context.Context, d *pluginsdk.ResourceD
 		ResourceId:  nullable.Value(resourceId),
 	}
 
-	resp, err := client.CreateAppRoleAssignedTo(ctx, stable.NewServicePrincipalID(resourceId), properties, approleassignedto.DefaultCreateAppRoleAssignedToOperationOptions())
+	retryOnMissingPrincipalEnabled := d.Get("retry_on_missing_principal").(bool)
+
+	var appRoleAssignment *stable.AppRoleAssignment
+	err := retryOnMissingPrincipal(ctx, d.Timeout(pluginsdk.TimeoutCreate), retryOnMissingPrincipalEnabled, func() error {
+		resp, err := client.CreateAppRoleAssignedTo(ctx, stable.NewServicePrincipalID(resourceId), properties, approleassignedto.DefaultCreateAppRoleAssignedToOperationOptions())
+		if err != nil {
+			return err
+		}
+		appRoleAssignment = resp.Model
+		return nil
+	})
 	if err != nil {
 		return tf.ErrorDiagF(err, "Could not create app role assignment")
 	}
 
-	appRoleAssignment := resp.Model
 	if appRoleAssignment == nil {
 		return tf.ErrorDiagF(errors.New("model was nil"), "Could not create app role assignment")
 	}
@@ -0,0 +1,167 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignedto"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+func appRoleAssignmentsDataSource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		ReadContext: appRoleAssignmentsDataSourceRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"resource_object_id": {
+				Description:      "The object ID of the service principal representing the resource",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"app_role_id": {
+				Description:      "Filter the assignments returned by the ID of the app role",
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"principal_object_id": {
+				Description:      "Filter the assignments returned by the object ID of the assigned principal",
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"principal_type": {
+				Description:  "Filter the assignments returned by the type of the assigned principal",
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"User", "Group", "ServicePrincipal"}, false),
+			},
+
+			"assignments": {
+				Description: "A list of app role assignments for the resource",
+				Type:        pluginsdk.TypeList,
+				Computed:    true,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"id": {
+							Description: "The ID of the app role assignment",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"app_role_id": {
+							Description: "The ID of the app role assigned",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"principal_object_id": {
+							Description: "The object ID of the assigned principal",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"principal_display_name": {
+							Description: "The display name of the assigned principal",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"principal_type": {
+							Description: "The object type of the assigned principal",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+
+						"created_date_time": {
+							Description: "The date and time at which the assignment was created, formatted as an RFC3339 date string",
+							Type:        pluginsdk.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func appRoleAssignmentsDataSourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.AppRoleAssignedToClient
+
+	resourceId := d.Get("resource_object_id").(string)
+	appRoleId := d.Get("app_role_id").(string)
+	principalId := d.Get("principal_object_id").(string)
+	principalType := d.Get("principal_type").(string)
+
+	options := approleassignedto.DefaultListAppRoleAssignedToOperationOptions()
+
+	if filter := buildAppRoleAssignedToFilter(appRoleId, principalId); filter != "" {
+		options.Filter = &filter
+	}
+
+	// Use the paginated Complete variant since a resource can have more app role assignments than
+	// fit on a single Graph page.
+	resp, err := client.ListAppRoleAssignedToComplete(ctx, stable.NewServicePrincipalID(resourceId), options)
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not list app role assignments for resource (Object ID: %q)", resourceId)
+	}
+
+	assignments := make([]interface{}, 0)
+	for _, assignment := range resp.Items {
+		if assignment.Id == nil {
+			continue
+		}
+
+		if principalType != "" && assignment.PrincipalType.GetOrZero() != principalType {
+			continue
+		}
+
+		assignments = append(assignments, map[string]interface{}{
+			"id":                     *assignment.Id,
+			"app_role_id":            pointer.From(assignment.AppRoleId),
+			"principal_object_id":    assignment.PrincipalId.GetOrZero(),
+			"principal_display_name": assignment.PrincipalDisplayName.GetOrZero(),
+			"principal_type":         assignment.PrincipalType.GetOrZero(),
+			"created_date_time":      assignment.CreatedDateTime.GetOrZero(),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%s/appRoleAssignments", resourceId))
+
+	tf.Set(d, "assignments", assignments)
+
+	return nil
+}
+
+// buildAppRoleAssignedToFilter builds an OData `$filter` clause for the optional appRoleId/
+// principalId arguments. Both are Edm.String properties in Microsoft Graph's schema, so their
+// literals must be single-quoted or Graph rejects the filter with a 400.
+func buildAppRoleAssignedToFilter(appRoleId, principalId string) string {
+	var filters []string
+	if appRoleId != "" {
+		filters = append(filters, fmt.Sprintf("appRoleId eq '%s'", appRoleId))
+	}
+	if principalId != "" {
+		filters = append(filters, fmt.Sprintf("principalId eq '%s'", principalId))
+	}
+
+	return strings.Join(filters, " and ")
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import "testing"
+
+func TestBuildAppRoleAssignedToFilter(t *testing.T) {
+	cases := []struct {
+		name        string
+		appRoleId   string
+		principalId string
+		expected    string
+	}{
+		{
+			name:     "no filters",
+			expected: "",
+		},
+		{
+			name:      "app role id only is quoted",
+			appRoleId: "11111111-1111-1111-1111-111111111111",
+			expected:  "appRoleId eq '11111111-1111-1111-1111-111111111111'",
+		},
+		{
+			name:        "principal id only is quoted",
+			principalId: "22222222-2222-2222-2222-222222222222",
+			expected:    "principalId eq '22222222-2222-2222-2222-222222222222'",
+		},
+		{
+			name:        "both filters are combined with and",
+			appRoleId:   "11111111-1111-1111-1111-111111111111",
+			principalId: "22222222-2222-2222-2222-222222222222",
+			expected:    "appRoleId eq '11111111-1111-1111-1111-111111111111' and principalId eq '22222222-2222-2222-2222-222222222222'",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := buildAppRoleAssignedToFilter(tc.appRoleId, tc.principalId); got != tc.expected {
+				t.Errorf("buildAppRoleAssignedToFilter(%q, %q) = %q, want %q", tc.appRoleId, tc.principalId, got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/approleassignedto"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/serviceprincipals/stable/serviceprincipal"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/approleassignments/parse"
+)
+
+const defaultAppRoleAssignmentsParallelism = 10
+
+func appRoleAssignmentsResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: appRoleAssignmentsResourceCreate,
+		ReadContext:   appRoleAssignmentsResourceRead,
+		UpdateContext: appRoleAssignmentsResourceUpdate,
+		DeleteContext: appRoleAssignmentsResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.AppRoleAssignmentsID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"app_role_id": {
+				Description:      "The ID of the app role to be assigned",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"resource_object_id": {
+				Description:      "The object ID of the service principal representing the resource",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"principal_object_ids": {
+				Description: "A set of object IDs of the users, groups or service principals to be assigned this app role",
+				Type:        pluginsdk.TypeSet,
+				Required:    true,
+				Elem: &pluginsdk.Schema{
+					Type:             pluginsdk.TypeString,
+					ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+				},
+			},
+
+			"parallelism": {
+				Description:  "The number of app role assignments to create or remove concurrently",
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      defaultAppRoleAssignmentsParallelism,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+
+			"assignment_ids": {
+				Description: "A mapping of assignment IDs keyed by principal object ID",
+				Type:        pluginsdk.TypeMap,
+				Computed:    true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"retry_on_missing_principal": {
+				Description: "Retry creation of an individual assignment if the principal or resource service principal has not yet replicated, up to the create timeout (or the update timeout for principals added on update)",
+				Type:        pluginsdk.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+		},
+	}
+}
+
+// appRoleAssignmentsFanOut runs `assign` for every principal ID in `principalIds`, bounded to
+// `parallelism` concurrent goroutines, and aggregates any per-principal failures into a single
+// error listing which principals failed.
+func appRoleAssignmentsFanOut(principalIds []string, parallelism int, assign func(principalId string) error) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+
+	var mu sync.Mutex
+	var failed []string
+	var errs []error
+
+	for _, principalId := range principalIds {
+		principalId := principalId
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := assign(principalId); err != nil {
+				mu.Lock()
+				failed = append(failed, principalId)
+				errs = append(errs, fmt.Errorf("%s: %v", principalId, err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed for %d principal(s): %v", len(failed), errs)
+	}
+
+	return nil
+}
+
+func appRoleAssignmentsResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.AppRoleAssignedToClient
+	servicePrincipalClient := meta.(*clients.Client).AppRoleAssignments.ServicePrincipalClient
+
+	appRoleId := d.Get("app_role_id").(string)
+	resourceId := d.Get("resource_object_id").(string)
+	principalIds := tf.ExpandStringSlice(d.Get("principal_object_ids").(*pluginsdk.Set).List())
+	parallelism := d.Get("parallelism").(int)
+	retryOnMissingPrincipalEnabled := d.Get("retry_on_missing_principal").(bool)
+
+	if resp, err := servicePrincipalClient.GetServicePrincipal(ctx, stable.NewServicePrincipalID(resourceId), serviceprincipal.DefaultGetServicePrincipalOperationOptions()); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return tf.ErrorDiagPathF(err, "resource_object_id", "Service principal not found for resource (Object ID: %q)", resourceId)
+		}
+		return tf.ErrorDiagF(err, "Could not retrieve service principal for resource (Object ID: %q)", resourceId)
+	}
+
+	assignmentIds := make(map[string]string)
+	var mu sync.Mutex
+
+	err := appRoleAssignmentsFanOut(principalIds, parallelism, func(principalId string) error {
+		properties := stable.AppRoleAssignment{
+			AppRoleId:   pointer.To(appRoleId),
+			PrincipalId: nullable.Value(principalId),
+			ResourceId:  nullable.Value(resourceId),
+		}
+
+		return retryOnMissingPrincipal(ctx, d.Timeout(pluginsdk.TimeoutCreate), retryOnMissingPrincipalEnabled, func() error {
+			resp, err := client.CreateAppRoleAssignedTo(ctx, stable.NewServicePrincipalID(resourceId), properties, approleassignedto.DefaultCreateAppRoleAssignedToOperationOptions())
+			if err != nil {
+				return err
+			}
+			if resp.Model == nil || resp.Model.Id == nil {
+				return errors.New("model was nil, or ID was nil, for app role assignment")
+			}
+
+			mu.Lock()
+			assignmentIds[principalId] = *resp.Model.Id
+			mu.Unlock()
+
+			return nil
+		})
+	})
+
+	// Persist whatever succeeded even if the fan-out returned an aggregated error, so that a
+	// partial failure leaves the successfully created assignments tracked in state rather than
+	// orphaned in Azure AD with no record in Terraform.
+	id := parse.NewAppRoleAssignmentsID(resourceId, appRoleId)
+	d.SetId(id.String())
+	tf.Set(d, "assignment_ids", assignmentIds)
+
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not create app role assignments")
+	}
+
+	return appRoleAssignmentsResourceRead(ctx, d, meta)
+}
+
+func appRoleAssignmentsResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.AppRoleAssignedToClient
+
+	id, err := parse.AppRoleAssignmentsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing app role assignments with ID %q", d.Id())
+	}
+
+	existingAssignmentIds := d.Get("assignment_ids").(map[string]interface{})
+
+	assignmentIds := make(map[string]string)
+	principalIds := make([]string, 0, len(existingAssignmentIds))
+	for principalId := range existingAssignmentIds {
+		principalIds = append(principalIds, principalId)
+	}
+
+	var mu sync.Mutex
+
+	parallelism := d.Get("parallelism").(int)
+	err = appRoleAssignmentsFanOut(principalIds, parallelism, func(principalId string) error {
+		assignmentId, ok := existingAssignmentIds[principalId].(string)
+		if !ok || assignmentId == "" {
+			return nil
+		}
+
+		resp, err := client.GetAppRoleAssignedTo(ctx, stable.NewServicePrincipalIdAppRoleAssignedToID(id.ResourceId, assignmentId), approleassignedto.DefaultGetAppRoleAssignedToOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return nil
+			}
+			return err
+		}
+
+		if resp.Model == nil || resp.Model.AppRoleId == nil || *resp.Model.AppRoleId != id.AppRoleId {
+			return nil
+		}
+
+		mu.Lock()
+		assignmentIds[principalId] = assignmentId
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		return tf.ErrorDiagF(err, "retrieving app role assignments %q", d.Id())
+	}
+
+	if len(assignmentIds) == 0 {
+		log.Printf("[DEBUG] App role assignments %q were not found - removing from state!", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	principalObjectIds := make([]string, 0, len(assignmentIds))
+	for principalId := range assignmentIds {
+		principalObjectIds = append(principalObjectIds, principalId)
+	}
+
+	tf.Set(d, "app_role_id", id.AppRoleId)
+	tf.Set(d, "resource_object_id", id.ResourceId)
+	tf.Set(d, "principal_object_ids", principalObjectIds)
+	tf.Set(d, "assignment_ids", assignmentIds)
+
+	return nil
+}
+
+func appRoleAssignmentsResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.AppRoleAssignedToClient
+
+	_, err := parse.AppRoleAssignmentsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing app role assignments with ID %q", d.Id())
+	}
+
+	appRoleId := d.Get("app_role_id").(string)
+	resourceId := d.Get("resource_object_id").(string)
+	parallelism := d.Get("parallelism").(int)
+	retryOnMissingPrincipalEnabled := d.Get("retry_on_missing_principal").(bool)
+
+	assignmentIds := d.Get("assignment_ids").(map[string]interface{})
+
+	oldRaw, newRaw := d.GetChange("principal_object_ids")
+	oldPrincipalIds := tf.ExpandStringSlice(oldRaw.(*pluginsdk.Set).List())
+	newPrincipalIds := tf.ExpandStringSlice(newRaw.(*pluginsdk.Set).List())
+
+	oldSet := make(map[string]bool, len(oldPrincipalIds))
+	for _, principalId := range oldPrincipalIds {
+		oldSet[principalId] = true
+	}
+	newSet := make(map[string]bool, len(newPrincipalIds))
+	for _, principalId := range newPrincipalIds {
+		newSet[principalId] = true
+	}
+
+	var toAdd, toRemove []string
+	for _, principalId := range newPrincipalIds {
+		if !oldSet[principalId] {
+			toAdd = append(toAdd, principalId)
+		}
+	}
+	for _, principalId := range oldPrincipalIds {
+		if !newSet[principalId] {
+			toRemove = append(toRemove, principalId)
+		}
+	}
+
+	var mu sync.Mutex
+
+	if err = appRoleAssignmentsFanOut(toRemove, parallelism, func(principalId string) error {
+		assignmentId, ok := assignmentIds[principalId].(string)
+		if !ok || assignmentId == "" {
+			return nil
+		}
+
+		if _, err := client.DeleteAppRoleAssignedTo(ctx, stable.NewServicePrincipalIdAppRoleAssignedToID(resourceId, assignmentId), approleassignedto.DefaultDeleteAppRoleAssignedToOperationOptions()); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		delete(assignmentIds, principalId)
+		mu.Unlock()
+
+		return nil
+	}); err != nil {
+		tf.Set(d, "assignment_ids", assignmentIds)
+		return tf.ErrorDiagF(err, "Could not remove app role assignments")
+	}
+
+	if err = appRoleAssignmentsFanOut(toAdd, parallelism, func(principalId string) error {
+		properties := stable.AppRoleAssignment{
+			AppRoleId:   pointer.To(appRoleId),
+			PrincipalId: nullable.Value(principalId),
+			ResourceId:  nullable.Value(resourceId),
+		}
+
+		return retryOnMissingPrincipal(ctx, d.Timeout(pluginsdk.TimeoutUpdate), retryOnMissingPrincipalEnabled, func() error {
+			resp, err := client.CreateAppRoleAssignedTo(ctx, stable.NewServicePrincipalID(resourceId), properties, approleassignedto.DefaultCreateAppRoleAssignedToOperationOptions())
+			if err != nil {
+				return err
+			}
+			if resp.Model == nil || resp.Model.Id == nil {
+				return errors.New("model was nil, or ID was nil, for app role assignment")
+			}
+
+			mu.Lock()
+			assignmentIds[principalId] = *resp.Model.Id
+			mu.Unlock()
+
+			return nil
+		})
+	}); err != nil {
+		tf.Set(d, "assignment_ids", assignmentIds)
+		return tf.ErrorDiagF(err, "Could not create app role assignments")
+	}
+
+	tf.Set(d, "assignment_ids", assignmentIds)
+
+	return appRoleAssignmentsResourceRead(ctx, d, meta)
+}
+
+func appRoleAssignmentsResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.AppRoleAssignedToClient
+
+	id, err := parse.AppRoleAssignmentsID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing app role assignments with ID %q", d.Id())
+	}
+
+	assignmentIds := d.Get("assignment_ids").(map[string]interface{})
+	principalIds := make([]string, 0, len(assignmentIds))
+	for principalId := range assignmentIds {
+		principalIds = append(principalIds, principalId)
+	}
+
+	parallelism := d.Get("parallelism").(int)
+
+	err = appRoleAssignmentsFanOut(principalIds, parallelism, func(principalId string) error {
+		assignmentId, ok := assignmentIds[principalId].(string)
+		if !ok || assignmentId == "" {
+			return nil
+		}
+
+		resp, err := client.DeleteAppRoleAssignedTo(ctx, stable.NewServicePrincipalIdAppRoleAssignedToID(id.ResourceId, assignmentId), approleassignedto.DefaultDeleteAppRoleAssignedToOperationOptions())
+		if err != nil {
+			if response.WasNotFound(resp.HttpResponse) {
+				return nil
+			}
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Deleting %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAppRoleAssignmentsFanOut_AllSucceed(t *testing.T) {
+	principalIds := []string{"p1", "p2", "p3"}
+
+	var mu sync.Mutex
+	var seen []string
+
+	err := appRoleAssignmentsFanOut(principalIds, 2, func(principalId string) error {
+		mu.Lock()
+		seen = append(seen, principalId)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(seen) != len(principalIds) {
+		t.Fatalf("expected %d principals to be processed, got %d", len(principalIds), len(seen))
+	}
+}
+
+func TestAppRoleAssignmentsFanOut_PartialFailureIsAggregated(t *testing.T) {
+	principalIds := []string{"p1", "p2", "p3"}
+
+	var succeeded int32
+
+	err := appRoleAssignmentsFanOut(principalIds, len(principalIds), func(principalId string) error {
+		if principalId == "p2" {
+			return fmt.Errorf("boom")
+		}
+		atomic.AddInt32(&succeeded, 1)
+		return nil
+	})
+
+	if err == nil {
+		t.Fatal("expected an aggregated error for the failing principal")
+	}
+	if !strings.Contains(err.Error(), "p2") {
+		t.Fatalf("expected aggregated error to mention failing principal %q, got %q", "p2", err.Error())
+	}
+	if succeeded != 2 {
+		t.Fatalf("expected the two non-failing principals to still be processed, got %d", succeeded)
+	}
+}
+
+func TestAppRoleAssignmentsFanOut_RespectsParallelismCeiling(t *testing.T) {
+	principalIds := make([]string, 20)
+	for i := range principalIds {
+		principalIds[i] = fmt.Sprintf("p%d", i)
+	}
+
+	var mu sync.Mutex
+	var current, max int
+
+	err := appRoleAssignmentsFanOut(principalIds, 3, func(principalId string) error {
+		mu.Lock()
+		current++
+		if current > max {
+			max = current
+		}
+		mu.Unlock()
+
+		mu.Lock()
+		current--
+		mu.Unlock()
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if max > 3 {
+		t.Fatalf("expected at most 3 concurrent executions, observed %d", max)
+	}
+}
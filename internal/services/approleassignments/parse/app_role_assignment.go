@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AppRoleAssignmentId is the composite ID for a single `azuread_app_role_assignment` resource,
+// combining the resource service principal's object ID with the assignment ID Microsoft Graph
+// issued when the assignment was created.
+type AppRoleAssignmentId struct {
+	ResourceId   string
+	AssignmentId string
+}
+
+func NewAppRoleAssignmentID(resourceId, assignmentId string) AppRoleAssignmentId {
+	return AppRoleAssignmentId{
+		ResourceId:   resourceId,
+		AssignmentId: assignmentId,
+	}
+}
+
+func (id AppRoleAssignmentId) String() string {
+	return fmt.Sprintf("%s/appRoleAssignment/%s", id.ResourceId, id.AssignmentId)
+}
+
+// AppRoleAssignmentID parses a string into an AppRoleAssignmentId.
+func AppRoleAssignmentID(idString string) (*AppRoleAssignmentId, error) {
+	parts := strings.Split(idString, "/appRoleAssignment/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expected an ID in the format %q but got %q", "{resourceId}/appRoleAssignment/{assignmentId}", idString)
+	}
+
+	return &AppRoleAssignmentId{
+		ResourceId:   parts[0],
+		AssignmentId: parts[1],
+	}, nil
+}
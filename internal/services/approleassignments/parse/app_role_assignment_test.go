@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import "testing"
+
+func TestAppRoleAssignmentID(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "valid", input: "resource1/appRoleAssignment/assignment1"},
+		{name: "missing assignment segment", input: "resource1/appRoleAssignment/", wantErr: true},
+		{name: "missing resource segment", input: "/appRoleAssignment/assignment1", wantErr: true},
+		{name: "not an app role assignment ID", input: "resource1/assignment1", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := AppRoleAssignmentID(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error parsing %q", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %v", tc.input, err)
+			}
+			if got.String() != tc.input {
+				t.Errorf("round-trip mismatch: got %q, want %q", got.String(), tc.input)
+			}
+		})
+	}
+}
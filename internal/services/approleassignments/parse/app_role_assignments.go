@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AppRoleAssignmentsId is the composite ID for an `azuread_app_role_assignments` resource, which
+// manages the full set of assignments of one app role to many principals on a single resource
+// service principal.
+type AppRoleAssignmentsId struct {
+	ResourceId string
+	AppRoleId  string
+}
+
+func NewAppRoleAssignmentsID(resourceId, appRoleId string) AppRoleAssignmentsId {
+	return AppRoleAssignmentsId{
+		ResourceId: resourceId,
+		AppRoleId:  appRoleId,
+	}
+}
+
+func (id AppRoleAssignmentsId) String() string {
+	return fmt.Sprintf("%s/appRoleAssignments/%s", id.ResourceId, id.AppRoleId)
+}
+
+// AppRoleAssignmentsID parses a string into an AppRoleAssignmentsId.
+func AppRoleAssignmentsID(idString string) (*AppRoleAssignmentsId, error) {
+	parts := strings.Split(idString, "/appRoleAssignments/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expected an ID in the format %q but got %q", "{resourceId}/appRoleAssignments/{appRoleId}", idString)
+	}
+
+	return &AppRoleAssignmentsId{
+		ResourceId: parts[0],
+		AppRoleId:  parts[1],
+	}, nil
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PimActiveAppRoleAssignmentId is the composite ID for an `azuread_pim_active_app_role_assignment`
+// resource, combining the resource service principal's object ID with the ID of the schedule
+// instance Microsoft Graph provisioned once the assignment request was granted.
+type PimActiveAppRoleAssignmentId struct {
+	ResourceId         string
+	ScheduleInstanceId string
+}
+
+func NewPimActiveAppRoleAssignmentID(resourceId, scheduleInstanceId string) PimActiveAppRoleAssignmentId {
+	return PimActiveAppRoleAssignmentId{
+		ResourceId:         resourceId,
+		ScheduleInstanceId: scheduleInstanceId,
+	}
+}
+
+func (id PimActiveAppRoleAssignmentId) String() string {
+	return fmt.Sprintf("%s/pimActiveAppRoleAssignment/%s", id.ResourceId, id.ScheduleInstanceId)
+}
+
+// PimActiveAppRoleAssignmentID parses a string into a PimActiveAppRoleAssignmentId.
+func PimActiveAppRoleAssignmentID(idString string) (*PimActiveAppRoleAssignmentId, error) {
+	parts := strings.Split(idString, "/pimActiveAppRoleAssignment/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expected an ID in the format %q but got %q", "{resourceId}/pimActiveAppRoleAssignment/{scheduleInstanceId}", idString)
+	}
+
+	return &PimActiveAppRoleAssignmentId{
+		ResourceId:         parts[0],
+		ScheduleInstanceId: parts[1],
+	}, nil
+}
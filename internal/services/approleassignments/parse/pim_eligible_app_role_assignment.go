@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PimEligibleAppRoleAssignmentId is the composite ID for an
+// `azuread_pim_eligible_app_role_assignment` resource, combining the resource service principal's
+// object ID with the ID of the schedule instance Microsoft Graph provisioned once the eligibility
+// request was granted.
+type PimEligibleAppRoleAssignmentId struct {
+	ResourceId         string
+	ScheduleInstanceId string
+}
+
+func NewPimEligibleAppRoleAssignmentID(resourceId, scheduleInstanceId string) PimEligibleAppRoleAssignmentId {
+	return PimEligibleAppRoleAssignmentId{
+		ResourceId:         resourceId,
+		ScheduleInstanceId: scheduleInstanceId,
+	}
+}
+
+func (id PimEligibleAppRoleAssignmentId) String() string {
+	return fmt.Sprintf("%s/pimEligibleAppRoleAssignment/%s", id.ResourceId, id.ScheduleInstanceId)
+}
+
+// PimEligibleAppRoleAssignmentID parses a string into a PimEligibleAppRoleAssignmentId.
+func PimEligibleAppRoleAssignmentID(idString string) (*PimEligibleAppRoleAssignmentId, error) {
+	parts := strings.Split(idString, "/pimEligibleAppRoleAssignment/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expected an ID in the format %q but got %q", "{resourceId}/pimEligibleAppRoleAssignment/{scheduleInstanceId}", idString)
+	}
+
+	return &PimEligibleAppRoleAssignmentId{
+		ResourceId:         parts[0],
+		ScheduleInstanceId: parts[1],
+	}, nil
+}
@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import "testing"
+
+func TestPimEligibleAppRoleAssignmentID(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "valid", input: "resource1/pimEligibleAppRoleAssignment/instance1"},
+		{name: "missing instance segment", input: "resource1/pimEligibleAppRoleAssignment/", wantErr: true},
+		{name: "missing resource segment", input: "/pimEligibleAppRoleAssignment/instance1", wantErr: true},
+		{name: "not a pim eligible app role assignment ID", input: "resource1/instance1", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := PimEligibleAppRoleAssignmentID(tc.input)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error parsing %q", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %v", tc.input, err)
+			}
+			if got.String() != tc.input {
+				t.Errorf("round-trip mismatch: got %q, want %q", got.String(), tc.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/roleassignmentschedulerequests"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/approleassignments/parse"
+)
+
+func pimActiveAppRoleAssignmentResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: pimActiveAppRoleAssignmentResourceCreate,
+		ReadContext:   pimActiveAppRoleAssignmentResourceRead,
+		DeleteContext: pimActiveAppRoleAssignmentResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(15 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(15 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.PimActiveAppRoleAssignmentID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"role_definition_id": {
+				Description:      "The ID of the directory role (built-in or custom unifiedRoleDefinition) to be assigned",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"principal_object_id": {
+				Description:      "The object ID of the user, group or service principal to be assigned this directory role",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"resource_object_id": {
+				Description:      "The object ID of the service principal representing the application to scope this directory role assignment to",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"schedule": schemaPimSchedule(),
+
+			"justification": schemaPimJustification(),
+
+			"ticket_info": schemaPimTicketInfo(),
+		},
+	}
+}
+
+func pimActiveAppRoleAssignmentResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.RoleAssignmentScheduleRequestsClient
+
+	roleDefinitionId := d.Get("role_definition_id").(string)
+	principalId := d.Get("principal_object_id").(string)
+	resourceId := d.Get("resource_object_id").(string)
+
+	properties := stable.UnifiedRoleAssignmentScheduleRequest{
+		Action:           pointer.To(stable.UnifiedRoleScheduleRequestActions_AdminAssign),
+		PrincipalId:      nullable.Value(principalId),
+		RoleDefinitionId: nullable.Value(roleDefinitionId),
+		AppScopeId:       nullable.Value(fmt.Sprintf("/%s", resourceId)),
+		ScheduleInfo:     expandPimSchedule(d.Get("schedule").([]interface{})),
+		Justification:    nullable.Value(d.Get("justification").(string)),
+		TicketInfo:       expandPimTicketInfo(d.Get("ticket_info").([]interface{})),
+	}
+
+	requestId, err := createAppRoleScheduleRequestWithRetry(ctx, func() (*string, error) {
+		resp, err := client.CreateRoleAssignmentScheduleRequest(ctx, properties, roleassignmentschedulerequests.DefaultCreateRoleAssignmentScheduleRequestOperationOptions())
+		if err != nil {
+			return nil, err
+		}
+		if resp.Model == nil || resp.Model.Id == nil {
+			return nil, errors.New("model was nil, or ID was nil, for role assignment schedule request")
+		}
+		return resp.Model.Id, nil
+	}, d.Timeout(pluginsdk.TimeoutCreate))
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not create PIM active app role assignment")
+	}
+
+	targetScheduleId, err := pollRoleAssignmentScheduleRequest(ctx, client, *requestId, d.Timeout(pluginsdk.TimeoutCreate))
+	if err != nil {
+		return tf.ErrorDiagF(err, "Waiting for PIM active app role assignment to be provisioned")
+	}
+
+	id := parse.NewPimActiveAppRoleAssignmentID(resourceId, targetScheduleId)
+	d.SetId(id.String())
+
+	return pimActiveAppRoleAssignmentResourceRead(ctx, d, meta)
+}
+
+func pimActiveAppRoleAssignmentResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.RoleAssignmentScheduleInstancesClient
+
+	id, err := parse.PimActiveAppRoleAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing PIM active app role assignment with ID %q", d.Id())
+	}
+
+	resp, err := client.GetRoleAssignmentScheduleInstance(ctx, stable.NewRoleManagementDirectoryRoleAssignmentScheduleInstanceID(id.ScheduleInstanceId))
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] PIM active app role assignment %q was not found - removing from state!", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "retrieving PIM active app role assignment %q", d.Id())
+	}
+
+	instance := resp.Model
+	if instance == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "retrieving PIM active app role assignment %q", d.Id())
+	}
+
+	tf.Set(d, "role_definition_id", instance.RoleDefinitionId.GetOrZero())
+	tf.Set(d, "principal_object_id", instance.PrincipalId.GetOrZero())
+	tf.Set(d, "resource_object_id", id.ResourceId)
+
+	if startDateTime := instance.StartDateTime.GetOrZero(); startDateTime != "" {
+		tf.Set(d, "schedule", setPimScheduleStartDateTime(d.Get("schedule").([]interface{}), startDateTime))
+	}
+
+	return nil
+}
+
+func pimActiveAppRoleAssignmentResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.RoleAssignmentScheduleRequestsClient
+
+	id, err := parse.PimActiveAppRoleAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing PIM active app role assignment with ID %q", d.Id())
+	}
+
+	properties := stable.UnifiedRoleAssignmentScheduleRequest{
+		Action:           pointer.To(stable.UnifiedRoleScheduleRequestActions_AdminRemove),
+		PrincipalId:      nullable.Value(d.Get("principal_object_id").(string)),
+		RoleDefinitionId: nullable.Value(d.Get("role_definition_id").(string)),
+		AppScopeId:       nullable.Value(fmt.Sprintf("/%s", id.ResourceId)),
+		Justification:    nullable.Value("Removed by Terraform"),
+	}
+
+	if _, err = client.CreateRoleAssignmentScheduleRequest(ctx, properties, roleassignmentschedulerequests.DefaultCreateRoleAssignmentScheduleRequestOperationOptions()); err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Removing %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
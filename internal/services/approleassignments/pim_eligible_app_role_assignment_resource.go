@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/roleeligibilityschedulerequests"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+	"github.com/hashicorp/terraform-provider-azuread/internal/services/approleassignments/parse"
+)
+
+func pimEligibleAppRoleAssignmentResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: pimEligibleAppRoleAssignmentResourceCreate,
+		ReadContext:   pimEligibleAppRoleAssignmentResourceRead,
+		DeleteContext: pimEligibleAppRoleAssignmentResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(15 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(15 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := parse.PimEligibleAppRoleAssignmentID(id)
+			return err
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"role_definition_id": {
+				Description:      "The ID of the directory role (built-in or custom unifiedRoleDefinition) to be made eligible",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"principal_object_id": {
+				Description:      "The object ID of the user, group or service principal to be made eligible for this directory role",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"resource_object_id": {
+				Description:      "The object ID of the service principal representing the application to scope this directory role eligibility to",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"schedule": schemaPimSchedule(),
+
+			"justification": schemaPimJustification(),
+
+			"ticket_info": schemaPimTicketInfo(),
+		},
+	}
+}
+
+func pimEligibleAppRoleAssignmentResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.RoleEligibilityScheduleRequestsClient
+
+	roleDefinitionId := d.Get("role_definition_id").(string)
+	principalId := d.Get("principal_object_id").(string)
+	resourceId := d.Get("resource_object_id").(string)
+
+	properties := stable.UnifiedRoleEligibilityScheduleRequest{
+		Action:           pointer.To(stable.UnifiedRoleScheduleRequestActions_AdminAssign),
+		PrincipalId:      nullable.Value(principalId),
+		RoleDefinitionId: nullable.Value(roleDefinitionId),
+		AppScopeId:       nullable.Value(fmt.Sprintf("/%s", resourceId)),
+		ScheduleInfo:     expandPimSchedule(d.Get("schedule").([]interface{})),
+		Justification:    nullable.Value(d.Get("justification").(string)),
+		TicketInfo:       expandPimTicketInfo(d.Get("ticket_info").([]interface{})),
+	}
+
+	requestId, err := createAppRoleScheduleRequestWithRetry(ctx, func() (*string, error) {
+		resp, err := client.CreateRoleEligibilityScheduleRequest(ctx, properties, roleeligibilityschedulerequests.DefaultCreateRoleEligibilityScheduleRequestOperationOptions())
+		if err != nil {
+			return nil, err
+		}
+		if resp.Model == nil || resp.Model.Id == nil {
+			return nil, errors.New("model was nil, or ID was nil, for role eligibility schedule request")
+		}
+		return resp.Model.Id, nil
+	}, d.Timeout(pluginsdk.TimeoutCreate))
+	if err != nil {
+		return tf.ErrorDiagF(err, "Could not create PIM eligible app role assignment")
+	}
+
+	targetScheduleId, err := pollRoleEligibilityScheduleRequest(ctx, client, *requestId, d.Timeout(pluginsdk.TimeoutCreate))
+	if err != nil {
+		return tf.ErrorDiagF(err, "Waiting for PIM eligible app role assignment to be provisioned")
+	}
+
+	id := parse.NewPimEligibleAppRoleAssignmentID(resourceId, targetScheduleId)
+	d.SetId(id.String())
+
+	return pimEligibleAppRoleAssignmentResourceRead(ctx, d, meta)
+}
+
+func pimEligibleAppRoleAssignmentResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.RoleEligibilityScheduleInstancesClient
+
+	id, err := parse.PimEligibleAppRoleAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing PIM eligible app role assignment with ID %q", d.Id())
+	}
+
+	resp, err := client.GetRoleEligibilityScheduleInstance(ctx, stable.NewRoleManagementDirectoryRoleEligibilityScheduleInstanceID(id.ScheduleInstanceId))
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] PIM eligible app role assignment %q was not found - removing from state!", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "retrieving PIM eligible app role assignment %q", d.Id())
+	}
+
+	instance := resp.Model
+	if instance == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "retrieving PIM eligible app role assignment %q", d.Id())
+	}
+
+	tf.Set(d, "role_definition_id", instance.RoleDefinitionId.GetOrZero())
+	tf.Set(d, "principal_object_id", instance.PrincipalId.GetOrZero())
+	tf.Set(d, "resource_object_id", id.ResourceId)
+
+	if startDateTime := instance.StartDateTime.GetOrZero(); startDateTime != "" {
+		tf.Set(d, "schedule", setPimScheduleStartDateTime(d.Get("schedule").([]interface{}), startDateTime))
+	}
+
+	return nil
+}
+
+func pimEligibleAppRoleAssignmentResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).AppRoleAssignments.RoleEligibilityScheduleRequestsClient
+
+	id, err := parse.PimEligibleAppRoleAssignmentID(d.Id())
+	if err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Parsing PIM eligible app role assignment with ID %q", d.Id())
+	}
+
+	properties := stable.UnifiedRoleEligibilityScheduleRequest{
+		Action:           pointer.To(stable.UnifiedRoleScheduleRequestActions_AdminRemove),
+		PrincipalId:      nullable.Value(d.Get("principal_object_id").(string)),
+		RoleDefinitionId: nullable.Value(d.Get("role_definition_id").(string)),
+		AppScopeId:       nullable.Value(fmt.Sprintf("/%s", id.ResourceId)),
+		Justification:    nullable.Value("Removed by Terraform"),
+	}
+
+	if _, err = client.CreateRoleEligibilityScheduleRequest(ctx, properties, roleeligibilityschedulerequests.DefaultCreateRoleEligibilityScheduleRequestOperationOptions()); err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Removing %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/roleassignmentschedulerequests"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/rolemanagement/stable/roleeligibilityschedulerequests"
+)
+
+const (
+	pimScheduleRequestStatusProvisioned = "Provisioned"
+	pimScheduleRequestStatusGranted     = "Granted"
+	pimScheduleRequestStatusFailed      = "Failed"
+	pimScheduleRequestStatusDenied      = "Denied"
+	pimScheduleRequestStatusCanceled    = "Canceled"
+)
+
+// createAppRoleScheduleRequestWithRetry retries submission of a PIM schedule request while the
+// principal or resource service principal has not yet replicated.
+func createAppRoleScheduleRequestWithRetry(ctx context.Context, submit func() (*string, error), timeout time.Duration) (*string, error) {
+	var requestId *string
+
+	err := retryOnMissingPrincipal(ctx, timeout, true, func() error {
+		var err error
+		requestId, err = submit()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return requestId, nil
+}
+
+// pollRoleAssignmentScheduleRequest polls a roleAssignmentScheduleRequests request until it
+// reaches a terminal status, returning the ID of the schedule instance it provisioned.
+func pollRoleAssignmentScheduleRequest(ctx context.Context, client *roleassignmentschedulerequests.RoleAssignmentScheduleRequestsClient, requestId string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		resp, err := client.GetRoleAssignmentScheduleRequest(ctx, stable.NewRoleManagementDirectoryRoleAssignmentScheduleRequestID(requestId), roleassignmentschedulerequests.DefaultGetRoleAssignmentScheduleRequestOperationOptions())
+		if err != nil {
+			return "", fmt.Errorf("retrieving role assignment schedule request %q: %+v", requestId, err)
+		}
+		if resp.Model == nil {
+			return "", errors.New("model was nil for role assignment schedule request")
+		}
+
+		status := resp.Model.Status.GetOrZero()
+		switch status {
+		case pimScheduleRequestStatusProvisioned, pimScheduleRequestStatusGranted:
+			if resp.Model.TargetScheduleId == nil || *resp.Model.TargetScheduleId == "" {
+				return "", errors.New("target schedule ID was not populated once request was provisioned")
+			}
+			return *resp.Model.TargetScheduleId, nil
+
+		case pimScheduleRequestStatusFailed, pimScheduleRequestStatusDenied, pimScheduleRequestStatusCanceled:
+			return "", fmt.Errorf("request reached terminal status %q", status)
+		}
+
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("timed out waiting for request to be provisioned (last status %q)", status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
+
+// pollRoleEligibilityScheduleRequest polls a roleEligibilityScheduleRequests request until it
+// reaches a terminal status, returning the ID of the schedule instance it provisioned.
+func pollRoleEligibilityScheduleRequest(ctx context.Context, client *roleeligibilityschedulerequests.RoleEligibilityScheduleRequestsClient, requestId string, timeout time.Duration) (string, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		resp, err := client.GetRoleEligibilityScheduleRequest(ctx, stable.NewRoleManagementDirectoryRoleEligibilityScheduleRequestID(requestId), roleeligibilityschedulerequests.DefaultGetRoleEligibilityScheduleRequestOperationOptions())
+		if err != nil {
+			return "", fmt.Errorf("retrieving role eligibility schedule request %q: %+v", requestId, err)
+		}
+		if resp.Model == nil {
+			return "", errors.New("model was nil for role eligibility schedule request")
+		}
+
+		status := resp.Model.Status.GetOrZero()
+		switch status {
+		case pimScheduleRequestStatusProvisioned, pimScheduleRequestStatusGranted:
+			if resp.Model.TargetScheduleId == nil || *resp.Model.TargetScheduleId == "" {
+				return "", errors.New("target schedule ID was not populated once request was provisioned")
+			}
+			return *resp.Model.TargetScheduleId, nil
+
+		case pimScheduleRequestStatusFailed, pimScheduleRequestStatusDenied, pimScheduleRequestStatusCanceled:
+			return "", fmt.Errorf("request reached terminal status %q", status)
+		}
+
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("timed out waiting for request to be provisioned (last status %q)", status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(10 * time.Second):
+		}
+	}
+}
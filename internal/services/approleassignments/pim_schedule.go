@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+// schemaPimSchedule returns the `schedule` block shared by the PIM active and eligible app role
+// assignment resources. Exactly one of `duration`, `end_date_time` or `no_expiration` must be set,
+// mirroring Microsoft Graph's `expirationPattern` type.
+func schemaPimSchedule() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Description: "The schedule for the assignment, controlling when it starts and when, if ever, it expires",
+		Type:        pluginsdk.TypeList,
+		Required:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"start_date_time": {
+					Description:      "The date and time the assignment becomes active, formatted as an RFC3339 date string. Defaults to the current time",
+					Type:             pluginsdk.TypeString,
+					Optional:         true,
+					Computed:         true,
+					ForceNew:         true,
+					ValidateDiagFunc: validation.ValidateDiag(validation.IsRFC3339Time),
+				},
+
+				"duration": {
+					Description:      "The length of time the assignment remains active for, in ISO8601 duration format",
+					Type:             pluginsdk.TypeString,
+					Optional:         true,
+					ForceNew:         true,
+					ExactlyOneOf:     []string{"schedule.0.duration", "schedule.0.end_date_time", "schedule.0.no_expiration"},
+					ValidateDiagFunc: validation.ValidateDiag(validation.IsISO8601Duration),
+				},
+
+				"end_date_time": {
+					Description:      "The date and time the assignment expires, formatted as an RFC3339 date string",
+					Type:             pluginsdk.TypeString,
+					Optional:         true,
+					ForceNew:         true,
+					ExactlyOneOf:     []string{"schedule.0.duration", "schedule.0.end_date_time", "schedule.0.no_expiration"},
+					ValidateDiagFunc: validation.ValidateDiag(validation.IsRFC3339Time),
+				},
+
+				"no_expiration": {
+					Description:  "Whether the assignment never expires",
+					Type:         pluginsdk.TypeBool,
+					Optional:     true,
+					ForceNew:     true,
+					ExactlyOneOf: []string{"schedule.0.duration", "schedule.0.end_date_time", "schedule.0.no_expiration"},
+				},
+			},
+		},
+	}
+}
+
+func expandPimSchedule(input []interface{}) *stable.RequestSchedule {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	raw := input[0].(map[string]interface{})
+
+	expiration := stable.ExpirationPattern{
+		Type: pointer.To(stable.ExpirationPatternType_NoExpiration),
+	}
+
+	if v, ok := raw["duration"].(string); ok && v != "" {
+		expiration.Type = pointer.To(stable.ExpirationPatternType_AfterDuration)
+		expiration.Duration = nullable.Value(v)
+	}
+
+	if v, ok := raw["end_date_time"].(string); ok && v != "" {
+		expiration.Type = pointer.To(stable.ExpirationPatternType_AfterDateTime)
+		expiration.EndDateTime = nullable.Value(v)
+	}
+
+	schedule := stable.RequestSchedule{
+		Expiration: &expiration,
+	}
+
+	if v, ok := raw["start_date_time"].(string); ok && v != "" {
+		schedule.StartDateTime = nullable.Value(v)
+	}
+
+	return &schedule
+}
+
+// setPimScheduleStartDateTime returns the `schedule` block to persist to state, with
+// `start_date_time` set to the value Microsoft Graph resolved (since it is Computed and defaults
+// to "now" when omitted from config), while leaving `duration`/`end_date_time`/`no_expiration` as
+// configured since Graph doesn't return them in the same shape on the schedule instance.
+func setPimScheduleStartDateTime(existing []interface{}, startDateTime string) []interface{} {
+	raw := map[string]interface{}{}
+	if len(existing) > 0 && existing[0] != nil {
+		raw = existing[0].(map[string]interface{})
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"start_date_time": startDateTime,
+			"duration":        raw["duration"],
+			"end_date_time":   raw["end_date_time"],
+			"no_expiration":   raw["no_expiration"],
+		},
+	}
+}
+
+// schemaPimJustification returns the `justification` argument shared by the PIM resources.
+func schemaPimJustification() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Description:  "A message provided by the administrator to justify the assignment",
+		Type:         pluginsdk.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.StringIsNotEmpty,
+	}
+}
+
+// schemaPimTicketInfo returns the optional `ticket_info` block shared by the PIM resources, used
+// to link the assignment to an entry in an external ticketing system.
+func schemaPimTicketInfo() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Description: "Ticketing information to associate with the assignment, for audit purposes",
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"ticket_number": {
+					Description:  "The ticket number in the external ticketing system",
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+
+				"ticket_system": {
+					Description:  "The name of the external ticketing system",
+					Type:         pluginsdk.TypeString,
+					Optional:     true,
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+func expandPimTicketInfo(input []interface{}) *stable.TicketInfo {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	raw := input[0].(map[string]interface{})
+
+	return &stable.TicketInfo{
+		TicketNumber: nullable.NoZero(raw["ticket_number"].(string)),
+		TicketSystem: nullable.NoZero(raw["ticket_system"].(string)),
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetPimScheduleStartDateTime(t *testing.T) {
+	cases := []struct {
+		name     string
+		existing []interface{}
+		resolved string
+		expected []interface{}
+	}{
+		{
+			name:     "no existing config",
+			existing: []interface{}{},
+			resolved: "2026-07-25T00:00:00Z",
+			expected: []interface{}{
+				map[string]interface{}{
+					"start_date_time": "2026-07-25T00:00:00Z",
+					"duration":        nil,
+					"end_date_time":   nil,
+					"no_expiration":   nil,
+				},
+			},
+		},
+		{
+			name: "resolved start time replaces computed value while other fields are preserved",
+			existing: []interface{}{
+				map[string]interface{}{
+					"start_date_time": "",
+					"duration":        "P30D",
+					"end_date_time":   "",
+					"no_expiration":   false,
+				},
+			},
+			resolved: "2026-07-25T12:00:00Z",
+			expected: []interface{}{
+				map[string]interface{}{
+					"start_date_time": "2026-07-25T12:00:00Z",
+					"duration":        "P30D",
+					"end_date_time":   "",
+					"no_expiration":   false,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := setPimScheduleStartDateTime(tc.existing, tc.resolved)
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("setPimScheduleStartDateTime() = %#v, want %#v", got, tc.expected)
+			}
+		})
+	}
+}
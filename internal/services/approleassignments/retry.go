@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// isTransientPrincipalError reports whether err looks like Microsoft Graph rejecting a request
+// because the principal or resource service principal has not yet replicated, as can happen
+// immediately after the service principal was created.
+func isTransientPrincipalError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	for _, substr := range []string{"PrincipalNotFound", "Request_ResourceNotFound", "ResourceNotFound"} {
+		if strings.Contains(msg, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryOnMissingPrincipalInitialWait is the backoff duration before the first retry. Exposed as a
+// var, rather than a constant, so tests can shrink it instead of waiting out the real interval.
+var retryOnMissingPrincipalInitialWait = 10 * time.Second
+
+// retryOnMissingPrincipalMaxWait caps the exponential backoff applied between retries.
+const retryOnMissingPrincipalMaxWait = 2 * time.Minute
+
+// retryOnMissingPrincipal retries fn with exponential backoff, up to timeout, while it fails with
+// a transient principal/resource-not-found error. This mirrors the retry behaviour AzureRM applies
+// when creating role assignments immediately after principal creation. When enabled is false, fn
+// is invoked exactly once.
+func retryOnMissingPrincipal(ctx context.Context, timeout time.Duration, enabled bool, fn func() error) error {
+	if !enabled {
+		return fn()
+	}
+
+	deadline := time.Now().Add(timeout)
+	wait := retryOnMissingPrincipalInitialWait
+
+	for {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		if !isTransientPrincipalError(err) || time.Now().After(deadline) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if wait *= 2; wait > retryOnMissingPrincipalMaxWait {
+			wait = retryOnMissingPrincipalMaxWait
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package approleassignments
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestIsTransientPrincipalError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "nil error", err: nil, expected: false},
+		{name: "unrelated error", err: errors.New("boom"), expected: false},
+		{name: "PrincipalNotFound", err: errors.New("400 PrincipalNotFound: principal has not replicated"), expected: true},
+		{name: "Request_ResourceNotFound", err: errors.New("404 Request_ResourceNotFound"), expected: true},
+		{name: "ResourceNotFound", err: errors.New("404 ResourceNotFound"), expected: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isTransientPrincipalError(tc.err); got != tc.expected {
+				t.Errorf("isTransientPrincipalError(%v) = %v, want %v", tc.err, got, tc.expected)
+			}
+		})
+	}
+}
+
+// withShortRetryBackoff swaps in a near-zero backoff for the duration of a test, so exercising the
+// retry loop doesn't block the test suite for the real 10s-plus backoff.
+func withShortRetryBackoff(t *testing.T) {
+	t.Helper()
+	original := retryOnMissingPrincipalInitialWait
+	retryOnMissingPrincipalInitialWait = time.Millisecond
+	t.Cleanup(func() {
+		retryOnMissingPrincipalInitialWait = original
+	})
+}
+
+func TestRetryOnMissingPrincipal_DisabledRunsOnce(t *testing.T) {
+	attempts := 0
+	err := retryOnMissingPrincipal(context.Background(), time.Minute, false, func() error {
+		attempts++
+		return errors.New("PrincipalNotFound")
+	})
+	if err == nil {
+		t.Fatal("expected the single attempt's error to be returned")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly 1 attempt when disabled, got %d", attempts)
+	}
+}
+
+func TestRetryOnMissingPrincipal_SucceedsAfterTransientFailures(t *testing.T) {
+	withShortRetryBackoff(t)
+
+	attempts := 0
+	err := retryOnMissingPrincipal(context.Background(), time.Minute, true, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("PrincipalNotFound")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetryOnMissingPrincipal_NonTransientErrorIsNotRetried(t *testing.T) {
+	withShortRetryBackoff(t)
+
+	attempts := 0
+	err := retryOnMissingPrincipal(context.Background(), time.Minute, true, func() error {
+		attempts++
+		return errors.New("some other failure")
+	})
+	if err == nil {
+		t.Fatal("expected an error to be returned")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected no retries for a non-transient error, got %d attempts", attempts)
+	}
+}
+
+func TestRetryOnMissingPrincipal_RespectsContextCancellation(t *testing.T) {
+	withShortRetryBackoff(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	attempts := 0
+	err := retryOnMissingPrincipal(ctx, time.Minute, true, func() error {
+		attempts++
+		return errors.New("PrincipalNotFound")
+	})
+	if err == nil {
+		t.Fatal("expected an error when the context is already cancelled")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected exactly 1 attempt before the cancelled context is observed, got %d", attempts)
+	}
+}
+
+func TestRetryOnMissingPrincipal_StopsRetryingPastDeadline(t *testing.T) {
+	withShortRetryBackoff(t)
+
+	attempts := 0
+	err := retryOnMissingPrincipal(context.Background(), time.Millisecond, true, func() error {
+		attempts++
+		time.Sleep(2 * time.Millisecond)
+		return errors.New("PrincipalNotFound")
+	})
+	if err == nil {
+		t.Fatal("expected the transient error to eventually be returned once the deadline passes")
+	}
+	if attempts < 1 {
+		t.Fatalf("expected at least 1 attempt, got %d", attempts)
+	}
+}
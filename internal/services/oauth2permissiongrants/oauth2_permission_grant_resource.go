@@ -0,0 +1,321 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oauth2permissiongrants
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/common-types/stable"
+	"github.com/hashicorp/go-azure-sdk/microsoft-graph/oauth2permissiongrants/stable/oauth2permissiongrant"
+	"github.com/hashicorp/go-azure-sdk/sdk/nullable"
+	"github.com/hashicorp/terraform-provider-azuread/internal/clients"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azuread/internal/helpers/tf/validation"
+)
+
+const (
+	consentTypeAllPrincipals = "AllPrincipals"
+	consentTypePrincipal     = "Principal"
+)
+
+func oAuth2PermissionGrantResource() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		CreateContext: oAuth2PermissionGrantResourceCreate,
+		ReadContext:   oAuth2PermissionGrantResourceRead,
+		UpdateContext: oAuth2PermissionGrantResourceUpdate,
+		DeleteContext: oAuth2PermissionGrantResourceDelete,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(5 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			return stable.ValidateOAuth2PermissionGrantID(id)
+		}),
+
+		Schema: map[string]*pluginsdk.Schema{
+			"client_id": {
+				Description:      "The object ID of the service principal for which to grant delegated permissions",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"resource_id": {
+				Description:      "The object ID of the service principal representing the resource API to grant delegated permissions for",
+				Type:             pluginsdk.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"consent_type": {
+				Description:  "Whether the grant is consented for all principals, or only for the principal given by `principal_id`",
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{consentTypeAllPrincipals, consentTypePrincipal}, false),
+			},
+
+			"principal_id": {
+				Description:      "The object ID of the user for which the grant is consented, when `consent_type` is `Principal`",
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validation.ValidateDiag(validation.IsUUID),
+			},
+
+			"scope": {
+				Description: "A set of delegated permission names to grant",
+				Type:        pluginsdk.TypeSet,
+				Required:    true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+func oAuth2PermissionGrantResourceCreate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).OAuth2PermissionGrants.OAuth2PermissionGrantClient
+
+	clientId := d.Get("client_id").(string)
+	resourceId := d.Get("resource_id").(string)
+	consentType := d.Get("consent_type").(string)
+	principalId := d.Get("principal_id").(string)
+	scope := strings.Join(tf.ExpandStringSlice(d.Get("scope").(*pluginsdk.Set).List()), " ")
+
+	if consentType == consentTypePrincipal && principalId == "" {
+		return tf.ErrorDiagPathF(errors.New("missing principal_id"), "principal_id", "`principal_id` must be specified when `consent_type` is %q", consentTypePrincipal)
+	}
+	if consentType == consentTypeAllPrincipals && principalId != "" {
+		return tf.ErrorDiagPathF(errors.New("unexpected principal_id"), "principal_id", "`principal_id` cannot be specified when `consent_type` is %q", consentTypeAllPrincipals)
+	}
+
+	properties := stable.OAuth2PermissionGrant{
+		ClientId:    nullable.Value(clientId),
+		ResourceId:  nullable.Value(resourceId),
+		ConsentType: nullable.Value(consentType),
+		Scope:       nullable.Value(scope),
+	}
+	if principalId != "" {
+		properties.PrincipalId = nullable.Value(principalId)
+	}
+
+	resp, err := client.CreateOAuth2PermissionGrant(ctx, properties, oauth2permissiongrant.DefaultCreateOAuth2PermissionGrantOperationOptions())
+	if err != nil {
+		if response.WasConflict(resp.HttpResponse) {
+			existing, findErr := findOAuth2PermissionGrant(ctx, client, clientId, resourceId, consentType, principalId)
+			if findErr != nil {
+				return tf.ErrorDiagF(findErr, "Could not reconcile existing oauth2PermissionGrant after conflict")
+			}
+			if existing == nil {
+				return tf.ErrorDiagF(err, "Could not create oauth2PermissionGrant")
+			}
+
+			if err = mergeOAuth2PermissionGrantScope(ctx, client, *existing.Id, existing.Scope.GetOrZero(), scope); err != nil {
+				return tf.ErrorDiagF(err, "Could not merge scope into existing oauth2PermissionGrant %q", *existing.Id)
+			}
+
+			d.SetId(*existing.Id)
+			return oAuth2PermissionGrantResourceRead(ctx, d, meta)
+		}
+		return tf.ErrorDiagF(err, "Could not create oauth2PermissionGrant")
+	}
+
+	if resp.Model == nil || resp.Model.Id == nil {
+		return tf.ErrorDiagF(errors.New("model was nil, or ID was nil, for oauth2PermissionGrant"), "Could not create oauth2PermissionGrant")
+	}
+
+	d.SetId(*resp.Model.Id)
+
+	return oAuth2PermissionGrantResourceRead(ctx, d, meta)
+}
+
+func oAuth2PermissionGrantResourceRead(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).OAuth2PermissionGrants.OAuth2PermissionGrantClient
+
+	id := stable.NewOAuth2PermissionGrantID(d.Id())
+
+	resp, err := client.GetOAuth2PermissionGrant(ctx, id, oauth2permissiongrant.DefaultGetOAuth2PermissionGrantOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state!", id)
+			d.SetId("")
+			return nil
+		}
+		return tf.ErrorDiagF(err, "retrieving %s", id)
+	}
+
+	grant := resp.Model
+	if grant == nil {
+		return tf.ErrorDiagF(errors.New("model was nil"), "retrieving %s", id)
+	}
+
+	tf.Set(d, "client_id", grant.ClientId.GetOrZero())
+	tf.Set(d, "resource_id", grant.ResourceId.GetOrZero())
+	tf.Set(d, "consent_type", grant.ConsentType.GetOrZero())
+	tf.Set(d, "principal_id", grant.PrincipalId.GetOrZero())
+	tf.Set(d, "scope", strings.Fields(grant.Scope.GetOrZero()))
+
+	return nil
+}
+
+func oAuth2PermissionGrantResourceUpdate(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).OAuth2PermissionGrants.OAuth2PermissionGrantClient
+
+	id := stable.NewOAuth2PermissionGrantID(d.Id())
+
+	// Unlike the 409-reconciliation path on create, config is the source of truth here: set
+	// `scope` to exactly what's configured rather than merging with whatever is on the server,
+	// otherwise removing a permission from config would never actually revoke it.
+	scope := strings.Join(tf.ExpandStringSlice(d.Get("scope").(*pluginsdk.Set).List()), " ")
+
+	properties := stable.OAuth2PermissionGrant{
+		Scope: nullable.Value(scope),
+	}
+
+	if _, err := client.UpdateOAuth2PermissionGrant(ctx, id, properties, oauth2permissiongrant.DefaultUpdateOAuth2PermissionGrantOperationOptions()); err != nil {
+		return tf.ErrorDiagF(err, "Could not update %s", id)
+	}
+
+	return oAuth2PermissionGrantResourceRead(ctx, d, meta)
+}
+
+func oAuth2PermissionGrantResourceDelete(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) pluginsdk.Diagnostics {
+	client := meta.(*clients.Client).OAuth2PermissionGrants.OAuth2PermissionGrantClient
+
+	id := stable.NewOAuth2PermissionGrantID(d.Id())
+
+	resp, err := client.GetOAuth2PermissionGrant(ctx, id, oauth2permissiongrant.DefaultGetOAuth2PermissionGrantOperationOptions())
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return tf.ErrorDiagPathF(err, "id", "retrieving %s: %v", id, err)
+	}
+	if resp.Model == nil {
+		return tf.ErrorDiagPathF(errors.New("model was nil"), "id", "retrieving %s", id)
+	}
+
+	ownScopes := tf.ExpandStringSlice(d.Get("scope").(*pluginsdk.Set).List())
+	remainingScope := subtractScopes(resp.Model.Scope.GetOrZero(), ownScopes)
+
+	// The create path can merge this resource's scope onto a grant another resource already owns,
+	// after Graph reports a 409 for the same (client, resource, principal) tuple. Deleting that
+	// shared grant outright would silently revoke the other resource's scope too, so only remove
+	// this resource's own contribution, and hard-delete the grant only once nothing remains.
+	if remainingScope == "" {
+		if _, err := client.DeleteOAuth2PermissionGrant(ctx, id, oauth2permissiongrant.DefaultDeleteOAuth2PermissionGrantOperationOptions()); err != nil {
+			return tf.ErrorDiagPathF(err, "id", "Deleting %s: %v", id, err)
+		}
+		return nil
+	}
+
+	properties := stable.OAuth2PermissionGrant{
+		Scope: nullable.Value(remainingScope),
+	}
+	if _, err := client.UpdateOAuth2PermissionGrant(ctx, id, properties, oauth2permissiongrant.DefaultUpdateOAuth2PermissionGrantOperationOptions()); err != nil {
+		return tf.ErrorDiagPathF(err, "id", "Removing this resource's scope from %s: %v", id, err)
+	}
+
+	return nil
+}
+
+// findOAuth2PermissionGrant looks for an existing grant matching the given (client, resource,
+// principal) tuple, used to reconcile a 409 returned when Graph has already merged a concurrently
+// created grant for the same tuple.
+func findOAuth2PermissionGrant(ctx context.Context, client *oauth2permissiongrant.OAuth2PermissionGrantClient, clientId, resourceId, consentType, principalId string) (*stable.OAuth2PermissionGrant, error) {
+	// Use the paginated Complete variant since the tenant's oauth2PermissionGrants collection can
+	// span more than one Graph page, and the grant being reconciled could be on any of them.
+	resp, err := client.ListOAuth2PermissionGrantsComplete(ctx, oauth2permissiongrant.DefaultListOAuth2PermissionGrantsOperationOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, grant := range resp.Items {
+		if grant.Id == nil {
+			continue
+		}
+		if grant.ClientId.GetOrZero() != clientId || grant.ResourceId.GetOrZero() != resourceId {
+			continue
+		}
+		if grant.ConsentType.GetOrZero() != consentType {
+			continue
+		}
+		if consentType == consentTypePrincipal && grant.PrincipalId.GetOrZero() != principalId {
+			continue
+		}
+
+		grant := grant
+		return &grant, nil
+	}
+
+	return nil, nil
+}
+
+// mergeOAuth2PermissionGrantScope updates the grant identified by id with the union of its
+// current scope and the desired scope, so that concurrently-applied configurations converge on a
+// superset of permissions rather than clobbering one another.
+func mergeOAuth2PermissionGrantScope(ctx context.Context, client *oauth2permissiongrant.OAuth2PermissionGrantClient, id, currentScope, desiredScope string) error {
+	merged := unionScopes(currentScope, desiredScope)
+
+	properties := stable.OAuth2PermissionGrant{
+		Scope: nullable.Value(merged),
+	}
+
+	_, err := client.UpdateOAuth2PermissionGrant(ctx, stable.NewOAuth2PermissionGrantID(id), properties, oauth2permissiongrant.DefaultUpdateOAuth2PermissionGrantOperationOptions())
+	return err
+}
+
+// subtractScopes removes each of remove from currentScope, returning the remaining scopes as a
+// sorted space-separated string.
+func subtractScopes(currentScope string, remove []string) string {
+	removeSet := make(map[string]bool, len(remove))
+	for _, scope := range remove {
+		removeSet[scope] = true
+	}
+
+	var remaining []string
+	for _, scope := range strings.Fields(currentScope) {
+		if !removeSet[scope] {
+			remaining = append(remaining, scope)
+		}
+	}
+
+	sort.Strings(remaining)
+
+	return strings.Join(remaining, " ")
+}
+
+func unionScopes(scopeLists ...string) string {
+	seen := make(map[string]bool)
+	var ordered []string
+
+	for _, scopeList := range scopeLists {
+		for _, scope := range strings.Fields(scopeList) {
+			if !seen[scope] {
+				seen[scope] = true
+				ordered = append(ordered, scope)
+			}
+		}
+	}
+
+	sort.Strings(ordered)
+
+	return strings.Join(ordered, " ")
+}
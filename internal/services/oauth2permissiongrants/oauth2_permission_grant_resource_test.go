@@ -0,0 +1,74 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oauth2permissiongrants
+
+import "testing"
+
+func TestUnionScopes(t *testing.T) {
+	cases := []struct {
+		name     string
+		scopes   []string
+		expected string
+	}{
+		{
+			name:     "disjoint scopes are combined",
+			scopes:   []string{"User.Read", "Mail.Send"},
+			expected: "Mail.Send User.Read",
+		},
+		{
+			name:     "duplicates across lists are deduplicated",
+			scopes:   []string{"User.Read Mail.Send", "Mail.Send"},
+			expected: "Mail.Send User.Read",
+		},
+		{
+			name:     "empty scope lists are ignored",
+			scopes:   []string{"", "User.Read"},
+			expected: "User.Read",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := unionScopes(tc.scopes...); got != tc.expected {
+				t.Errorf("unionScopes(%v) = %q, want %q", tc.scopes, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSubtractScopes(t *testing.T) {
+	cases := []struct {
+		name         string
+		currentScope string
+		remove       []string
+		expected     string
+	}{
+		{
+			name:         "removing own scope from a solely-owned grant leaves nothing",
+			currentScope: "User.Read Mail.Send",
+			remove:       []string{"User.Read", "Mail.Send"},
+			expected:     "",
+		},
+		{
+			name:         "another resource's merged-in scope survives removal of this resource's own",
+			currentScope: "User.Read Mail.Send",
+			remove:       []string{"Mail.Send"},
+			expected:     "User.Read",
+		},
+		{
+			name:         "removing scopes not present is a no-op",
+			currentScope: "User.Read",
+			remove:       []string{"Mail.Send"},
+			expected:     "User.Read",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := subtractScopes(tc.currentScope, tc.remove); got != tc.expected {
+				t.Errorf("subtractScopes(%q, %v) = %q, want %q", tc.currentScope, tc.remove, got, tc.expected)
+			}
+		})
+	}
+}